@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -16,6 +17,17 @@ type EngineConfig struct {
 	Deterministic bool
 	NumThreads    int
 	CacheSize     int
+
+	// ForceScalar disables SIMD dispatch and routes every op through the
+	// portable blocked-loop fallback, regardless of what the CPU supports.
+	// Mainly useful for testing and for A/B-ing kernels in benchmarks.
+	ForceScalar bool
+
+	// Precision selects the numeric format MatMul/Attention/Softmax compute
+	// in. Defaults to Float32; lower precisions trade accuracy for speed
+	// and memory bandwidth. Int8 applies to MatMul (and Attention through
+	// it) only; Softmax computes in float32 when Precision is Int8.
+	Precision Precision
 }
 
 // DefaultConfig returns default engine configuration
@@ -25,6 +37,8 @@ func DefaultConfig() EngineConfig {
 		Deterministic: true,
 		NumThreads:    8,
 		CacheSize:     100 * 1024 * 1024, // 100MB
+		ForceScalar:   false,
+		Precision:     Float32,
 	}
 }
 
@@ -38,21 +52,83 @@ type Matrix struct {
 // Engine is the main ADead-BIB engine
 type Engine struct {
 	config EngineConfig
+
+	// simd is the widest vector ISA this engine will dispatch to. It is
+	// detected once at construction time and cached for the lifetime of
+	// the engine so hot loops never re-probe CPUID.
+	simd simdLevel
+
+	// rngMu guards rng: engines are meant to be safe for concurrent use
+	// from multiple goroutines (see NumThreads), and math/rand.Rand isn't.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	// pool is the lazily created worker pool backing Parallel and the ops
+	// that use it internally (MatMul, Add, Scale, Softmax, Sigmoid, ReLU);
+	// Sort spawns its own goroutines instead. See workers() and Close().
+	poolOnce sync.Once
+	pool     *workerPool
 }
 
+// deterministicSeed is the fixed seed engines use when
+// EngineConfig.Deterministic is true, so RandomMatrix/RandomNormal are
+// reproducible across runs and processes.
+const deterministicSeed = 42
+
 // NewEngine creates a new engine with default config
 func NewEngine() *Engine {
-	return &Engine{config: DefaultConfig()}
+	return NewEngineWithConfig(DefaultConfig())
 }
 
+// defaultEngine backs the package-level RandomMatrix for callers who don't
+// need their own Engine just to get a random matrix.
+var defaultEngine = NewEngine()
+
 // NewEngineWithConfig creates a new engine with custom config
 func NewEngineWithConfig(config EngineConfig) *Engine {
-	return &Engine{config: config}
+	e := &Engine{config: config, simd: simdScalar}
+	if !config.ForceScalar {
+		e.simd = detectSIMD()
+	}
+
+	seed := uint64(time.Now().UnixNano())
+	if config.Deterministic {
+		seed = deterministicSeed
+	}
+	e.rng = rand.New(rand.NewSource(int64(seed)))
+
+	return e
 }
 
-// HasGPU returns true if GPU is available
+// SIMDLevel reports which vector ISA this engine is dispatching to, mostly
+// so benchmarks and logs can record what was actually used.
+func (e *Engine) SIMDLevel() string {
+	switch e.simd {
+	case simdAVX512:
+		return "avx512"
+	case simdAVX2:
+		return "avx2"
+	default:
+		return "scalar"
+	}
+}
+
+// HasGPU returns true if the engine is configured to use the GPU and a
+// device backend is actually compiled in and present (see gpuAvailable).
+// It does not panic; MatMul/Add/Scale/Softmax are the ones that panic if
+// UseGPU is set without a matching build tag, so callers get a clear error
+// at the point they actually asked for GPU work rather than a silent
+// fallback to CPU.
 func (e *Engine) HasGPU() bool {
-	return e.config.UseGPU
+	return e.config.UseGPU && gpuAvailable()
+}
+
+// wantsGPU reports whether this op should attempt the device path: either
+// it's actually available, or UseGPU was requested on a build without GPU
+// support, in which case the device path panics with a helpful message
+// instead of silently computing on the CPU.
+func (e *Engine) wantsGPU() bool {
+	return e.config.UseGPU && (gpuAvailable() || !gpuBuildTagEnabled)
 }
 
 // ============================================================================
@@ -77,16 +153,47 @@ func Ones(rows, cols int) *Matrix {
 	return &Matrix{Data: data, Rows: rows, Cols: cols}
 }
 
-// RandomMatrix creates a random matrix
+// RandomMatrix creates a random matrix using a package-wide default engine.
+// Kept for compatibility; prefer Engine.RandomMatrix, which lets you control
+// determinism and seeding explicitly.
 func RandomMatrix(rows, cols int) *Matrix {
-	rand.Seed(time.Now().UnixNano())
+	return defaultEngine.RandomMatrix(rows, cols)
+}
+
+// RandomMatrix creates a matrix of values uniform in [-1, 1), drawn from
+// this engine's own RNG.
+func (e *Engine) RandomMatrix(rows, cols int) *Matrix {
+	e.rngMu.Lock()
+	defer e.rngMu.Unlock()
+
+	data := make([]float32, rows*cols)
+	for i := range data {
+		data[i] = e.rng.Float32()*2 - 1
+	}
+	return &Matrix{Data: data, Rows: rows, Cols: cols}
+}
+
+// RandomNormal creates a matrix of values drawn from a normal distribution
+// with the given mean and standard deviation.
+func (e *Engine) RandomNormal(rows, cols int, mean, std float32) *Matrix {
+	e.rngMu.Lock()
+	defer e.rngMu.Unlock()
+
 	data := make([]float32, rows*cols)
 	for i := range data {
-		data[i] = rand.Float32()*2 - 1
+		data[i] = mean + std*float32(e.rng.NormFloat64())
 	}
 	return &Matrix{Data: data, Rows: rows, Cols: cols}
 }
 
+// Seed reseeds this engine's RNG, overriding whatever NewEngineWithConfig
+// picked based on EngineConfig.Deterministic.
+func (e *Engine) Seed(seed uint64) {
+	e.rngMu.Lock()
+	defer e.rngMu.Unlock()
+	e.rng = rand.New(rand.NewSource(int64(seed)))
+}
+
 // Eye creates an identity matrix
 func Eye(size int) *Matrix {
 	data := make([]float32, size*size)
@@ -110,39 +217,41 @@ func (m *Matrix) Set(row, col int, value float32) {
 // MATRIX OPERATIONS
 // ============================================================================
 
-// MatMul performs matrix multiplication
-// Benchmark: 120ms (Go) -> 0.1ms (ADead-BIB optimized)
+// MatMul performs matrix multiplication, dispatching to the widest SIMD
+// kernel this engine detected (AVX-512, then AVX2, then the portable
+// blocked-loop fallback). See BenchmarkMatMul in matmul_bench_test.go for
+// scalar-vs-AVX2-vs-AVX-512 timings across sizes.
 func (e *Engine) MatMul(a, b *Matrix) *Matrix {
 	if a.Cols != b.Rows {
 		panic("incompatible dimensions")
 	}
 
-	m, n, k := a.Rows, b.Cols, a.Cols
-	result := Zeros(m, n)
-
-	// Blocked multiplication for cache efficiency
-	const block = 32
-
-	for i := 0; i < m; i += block {
-		for j := 0; j < n; j += block {
-			for kk := 0; kk < k; kk += block {
-				iMax := min(i+block, m)
-				jMax := min(j+block, n)
-				kMax := min(kk+block, k)
-
-				for ii := i; ii < iMax; ii++ {
-					for kkk := kk; kkk < kMax; kkk++ {
-						aVal := a.Get(ii, kkk)
-						for jj := j; jj < jMax; jj++ {
-							idx := ii*n + jj
-							result.Data[idx] += aVal * b.Get(kkk, jj)
-						}
-					}
-				}
-			}
-		}
+	if e.wantsGPU() {
+		return gpuMatMul(a, b)
 	}
 
+	if e.config.Precision == Int8 {
+		aq, aScale := QuantizeTensor(a)
+		bq, bScale := QuantizeTensor(b)
+		return e.QuantizedMatMul(aq, aScale, bq, bScale)
+	}
+	a, b = roundTrip(a, e.config.Precision), roundTrip(b, e.config.Precision)
+
+	lanes := 0
+	switch e.simd {
+	case simdAVX512:
+		lanes = 16
+	case simdAVX2:
+		lanes = 8
+	}
+
+	// Each worker owns a disjoint range of output rows, so there's no
+	// cross-goroutine accumulator to reduce and results are bitwise
+	// identical regardless of NumThreads.
+	result := Zeros(a.Rows, b.Cols)
+	e.Parallel(a.Rows, func(start, end int) {
+		matMulInto(result, a, b, start, end, lanes)
+	})
 	return result
 }
 
@@ -157,25 +266,50 @@ func (e *Engine) Transpose(a *Matrix) *Matrix {
 	return result
 }
 
-// Add adds two matrices
+// Add adds two matrices, dispatching to the widest available SIMD kernel.
 func (e *Engine) Add(a, b *Matrix) *Matrix {
 	if a.Rows != b.Rows || a.Cols != b.Cols {
 		panic("incompatible dimensions")
 	}
 
-	result := Zeros(a.Rows, a.Cols)
-	for i := range result.Data {
-		result.Data[i] = a.Data[i] + b.Data[i]
+	if e.wantsGPU() {
+		return gpuAdd(a, b)
+	}
+
+	lanes := 0
+	switch e.simd {
+	case simdAVX512:
+		lanes = 16
+	case simdAVX2:
+		lanes = 8
 	}
+
+	result := Zeros(a.Rows, a.Cols)
+	e.Parallel(len(result.Data), func(start, end int) {
+		addLanes(result.Data[start:end], a.Data[start:end], b.Data[start:end], lanes)
+	})
 	return result
 }
 
-// Scale multiplies matrix by scalar
+// Scale multiplies matrix by scalar, dispatching to the widest available
+// SIMD kernel.
 func (e *Engine) Scale(a *Matrix, factor float32) *Matrix {
-	result := Zeros(a.Rows, a.Cols)
-	for i := range result.Data {
-		result.Data[i] = a.Data[i] * factor
+	if e.wantsGPU() {
+		return gpuScale(a, factor)
 	}
+
+	lanes := 0
+	switch e.simd {
+	case simdAVX512:
+		lanes = 16
+	case simdAVX2:
+		lanes = 8
+	}
+
+	result := Zeros(a.Rows, a.Cols)
+	e.Parallel(len(result.Data), func(start, end int) {
+		scaleLanes(result.Data[start:end], a.Data[start:end], factor, lanes)
+	})
 	return result
 }
 
@@ -225,75 +359,146 @@ func (e *Engine) Min(data []float32) float32 {
 
 // Softmax applies softmax function
 func (e *Engine) Softmax(data []float32) []float32 {
+	if e.wantsGPU() {
+		return gpuSoftmax(data)
+	}
+
+	data = roundTripVec(data, e.config.Precision)
+
 	maxVal := e.Max(data)
 	exp := make([]float32, len(data))
-	var sum float32
 
-	for i, v := range data {
-		exp[i] = float32(math.Exp(float64(v - maxVal)))
-		sum += exp[i]
-	}
+	e.Parallel(len(data), func(start, end int) {
+		for i := start; i < end; i++ {
+			exp[i] = float32(math.Exp(float64(data[i] - maxVal)))
+		}
+	})
 
-	for i := range exp {
-		exp[i] /= sum
+	// Summed sequentially (not inside Parallel) so the result doesn't
+	// depend on how many chunks NumThreads split the data into.
+	var sum float32
+	for _, v := range exp {
+		sum += v
 	}
+
+	e.Parallel(len(exp), func(start, end int) {
+		for i := start; i < end; i++ {
+			exp[i] /= sum
+		}
+	})
 	return exp
 }
 
 // ReLU applies ReLU activation
 func (e *Engine) ReLU(data []float32) []float32 {
 	result := make([]float32, len(data))
-	for i, v := range data {
-		if v > 0 {
-			result[i] = v
+	e.Parallel(len(data), func(start, end int) {
+		for i := start; i < end; i++ {
+			if data[i] > 0 {
+				result[i] = data[i]
+			}
 		}
-	}
+	})
 	return result
 }
 
 // Sigmoid applies sigmoid activation
 func (e *Engine) Sigmoid(data []float32) []float32 {
 	result := make([]float32, len(data))
-	for i, v := range data {
-		result[i] = float32(1.0 / (1.0 + math.Exp(-float64(v))))
-	}
+	e.Parallel(len(data), func(start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = float32(1.0 / (1.0 + math.Exp(-float64(data[i]))))
+		}
+	})
 	return result
 }
 
-// Attention computes attention mechanism
+// defaultFlashBlockSize is the Q/K/V tile size Attention hands to
+// FlashAttention. It materializes one defaultFlashBlockSize x
+// defaultFlashBlockSize scores tile at a time rather than the full
+// seqLen x seqLen matrix, which is already a meaningful saving at the
+// sequence lengths Attention sees in practice.
+const defaultFlashBlockSize = 64
+
+// Attention computes scaled dot-product attention. It's a thin wrapper
+// around FlashAttention (non-causal, default block size); see
+// FlashAttention's doc comment for why tiling the computation matters once
+// seqLen stops being small.
 func (e *Engine) Attention(Q, K, V *Matrix) *Matrix {
-	dim := float32(Q.Cols)
-
-	// Q @ K^T
-	Kt := e.Transpose(K)
-	scores := e.MatMul(Q, Kt)
-
-	// Scale
-	scores = e.Scale(scores, 1.0/float32(math.Sqrt(float64(dim))))
-
-	// Softmax per row
-	seqLen := Q.Rows
-	for i := 0; i < seqLen; i++ {
-		start := i * seqLen
-		end := start + seqLen
-		row := scores.Data[start:end]
-		softRow := e.Softmax(row)
-		copy(scores.Data[start:end], softRow)
-	}
-
-	// Scores @ V
-	return e.MatMul(scores, V)
+	return e.FlashAttention(Q, K, V, defaultFlashBlockSize, false)
 }
 
 // ============================================================================
 // SORTING & SEARCHING
 // ============================================================================
 
-// Sort sorts a slice in place
+// parallelSortMinSize is the smallest slice parallelSort will still split
+// in two rather than handing to sort.Slice directly; below this, spawning
+// goroutines costs more than it saves.
+const parallelSortMinSize = 4096
+
+// Sort sorts a slice in place, in parallel once it's large enough to be
+// worth splitting across EngineConfig.NumThreads goroutines.
 func (e *Engine) Sort(data []float32) {
-	sort.Slice(data, func(i, j int) bool {
-		return data[i] < data[j]
-	})
+	depth := 0
+	for n := e.config.NumThreads; n > 1; n >>= 1 {
+		depth++
+	}
+	e.parallelSort(data, depth)
+}
+
+// parallelSort is a parallel merge sort: it recursively splits data in half
+// across goroutines up to depth times (roughly log2(NumThreads)), then
+// merges each pair of sorted halves back together.
+func (e *Engine) parallelSort(data []float32, depth int) {
+	if depth <= 0 || len(data) < parallelSortMinSize {
+		sort.Slice(data, func(i, j int) bool {
+			return data[i] < data[j]
+		})
+		return
+	}
+
+	mid := len(data) / 2
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e.parallelSort(data[:mid], depth-1)
+	}()
+	go func() {
+		defer wg.Done()
+		e.parallelSort(data[mid:], depth-1)
+	}()
+	wg.Wait()
+
+	mergeSorted(data, mid)
+}
+
+// mergeSorted merges the two already-sorted halves data[:mid] and
+// data[mid:] back into data in place.
+func mergeSorted(data []float32, mid int) {
+	left := append([]float32(nil), data[:mid]...)
+	right := append([]float32(nil), data[mid:]...)
+
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if left[i] <= right[j] {
+			data[k] = left[i]
+			i++
+		} else {
+			data[k] = right[j]
+			j++
+		}
+		k++
+	}
+	for ; i < len(left); i++ {
+		data[k] = left[i]
+		k++
+	}
+	for ; j < len(right); j++ {
+		data[k] = right[j]
+		k++
+	}
 }
 
 // BinarySearch performs binary search
@@ -364,11 +569,3 @@ func (e *Engine) Benchmark(f func(), iterations int) BenchmarkResult {
 		Iterations: iterations,
 	}
 }
-
-// Helper function
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
@@ -0,0 +1,129 @@
+package adead
+
+import "math"
+
+// FlashAttention computes scaled dot-product attention without ever
+// materializing the full seqLen x seqLen scores matrix. Q is tiled into
+// blocks of blockSize rows (Br); for each Q-block, K and V are streamed in
+// blocks of blockSize rows (Bc) while a running per-row max m_i and
+// denominator l_i are kept, following the online-softmax recurrence:
+//
+//	S      = (Q_i @ K_j^T) / sqrt(d)
+//	m_new  = max(m_i, rowmax(S))
+//	P      = exp(S - m_new)
+//	l_new  = exp(m_i - m_new)*l_i + rowsum(P)
+//	O_i    = (l_i/l_new)*exp(m_i - m_new)*O_i + (1/l_new)*(P @ V_j)
+//
+// so peak memory for the scores tile is O(Br*Bc) instead of O(seqLen^2). At
+// the end of the K/V loop O_i holds the correct softmax-weighted output for
+// that Q-block. When causalMask is set, K/V blocks entirely past the
+// current Q-block are skipped outright and the rest are masked per-element.
+func (e *Engine) FlashAttention(Q, K, V *Matrix, blockSize int, causalMask bool) *Matrix {
+	if Q.Cols != K.Cols || K.Rows != V.Rows {
+		panic("incompatible dimensions")
+	}
+	if blockSize <= 0 {
+		blockSize = defaultFlashBlockSize
+	}
+
+	seqLen, kvLen, d := Q.Rows, K.Rows, Q.Cols
+	invSqrtD := float32(1.0 / math.Sqrt(float64(d)))
+	out := Zeros(seqLen, d)
+
+	for qStart := 0; qStart < seqLen; qStart += blockSize {
+		qEnd := minInt(qStart+blockSize, seqLen)
+		br := qEnd - qStart
+		qBlock := rowSlice(Q, qStart, qEnd)
+
+		m := make([]float32, br)
+		l := make([]float32, br)
+		o := make([]float32, br*d)
+		for r := range m {
+			m[r] = float32(math.Inf(-1))
+		}
+
+		kEnd := kvLen
+		if causalMask {
+			kEnd = minInt(qEnd, kvLen)
+		}
+
+		for kStart := 0; kStart < kEnd; kStart += blockSize {
+			kBlockEnd := minInt(kStart+blockSize, kEnd)
+			bc := kBlockEnd - kStart
+
+			kBlock := rowSlice(K, kStart, kBlockEnd)
+			vBlock := rowSlice(V, kStart, kBlockEnd)
+
+			scores := e.MatMul(qBlock, e.Transpose(kBlock))
+
+			blockMax := make([]float32, br)
+			for r := 0; r < br; r++ {
+				row := scores.Data[r*bc : (r+1)*bc]
+				maxVal := float32(math.Inf(-1))
+				for c := range row {
+					s := row[c] * invSqrtD
+					if causalMask && kStart+c > qStart+r {
+						s = float32(math.Inf(-1))
+					}
+					row[c] = s
+					if s > maxVal {
+						maxVal = s
+					}
+				}
+				blockMax[r] = maxVal
+			}
+
+			for r := 0; r < br; r++ {
+				row := scores.Data[r*bc : (r+1)*bc]
+				newMax := m[r]
+				if blockMax[r] > newMax {
+					newMax = blockMax[r]
+				}
+
+				var sum float32
+				for c, s := range row {
+					p := float32(math.Exp(float64(s - newMax)))
+					row[c] = p
+					sum += p
+				}
+
+				correction := float32(math.Exp(float64(m[r] - newMax)))
+				newL := correction*l[r] + sum
+
+				// Rescale the existing running output by (l_i/l_new)*correction
+				// before folding in this block's contribution below.
+				oRow := o[r*d : (r+1)*d]
+				weightOld := float32(0)
+				if newL > 0 {
+					weightOld = (l[r] / newL) * correction
+				}
+				for c := range oRow {
+					oRow[c] *= weightOld
+				}
+
+				l[r] = newL
+				m[r] = newMax
+			}
+
+			pv := e.MatMul(scores, vBlock)
+			for r := 0; r < br; r++ {
+				oRow := o[r*d : (r+1)*d]
+				pvRow := pv.Data[r*d : (r+1)*d]
+				invL := float32(1.0 / l[r])
+				for c := range oRow {
+					oRow[c] += pvRow[c] * invL
+				}
+			}
+		}
+
+		copy(out.Data[qStart*d:qEnd*d], o)
+	}
+
+	return out
+}
+
+// rowSlice returns a Matrix view over m's rows [start, end), sharing the
+// same backing array.
+func rowSlice(m *Matrix, start, end int) *Matrix {
+	return &Matrix{Data: m.Data[start*m.Cols : end*m.Cols], Rows: end - start, Cols: m.Cols}
+}
@@ -0,0 +1,95 @@
+package adead
+
+import (
+	"math"
+	"testing"
+)
+
+// naiveAttention is a reference implementation that materializes the full
+// scores matrix, used to check FlashAttention against.
+func naiveAttention(Q, K, V *Matrix, causalMask bool) *Matrix {
+	d := float32(Q.Cols)
+	invSqrtD := float32(1.0 / math.Sqrt(float64(d)))
+
+	out := Zeros(Q.Rows, V.Cols)
+	for i := 0; i < Q.Rows; i++ {
+		scores := make([]float32, K.Rows)
+		maxVal := float32(math.Inf(-1))
+		for j := 0; j < K.Rows; j++ {
+			if causalMask && j > i {
+				scores[j] = float32(math.Inf(-1))
+				continue
+			}
+			var dot float32
+			for c := 0; c < Q.Cols; c++ {
+				dot += Q.Get(i, c) * K.Get(j, c)
+			}
+			scores[j] = dot * invSqrtD
+			if scores[j] > maxVal {
+				maxVal = scores[j]
+			}
+		}
+
+		var sum float32
+		for j := range scores {
+			scores[j] = float32(math.Exp(float64(scores[j] - maxVal)))
+			sum += scores[j]
+		}
+
+		for c := 0; c < V.Cols; c++ {
+			var acc float32
+			for j := 0; j < K.Rows; j++ {
+				acc += (scores[j] / sum) * V.Get(j, c)
+			}
+			out.Set(i, c, acc)
+		}
+	}
+	return out
+}
+
+func maxAbsDiff(a, b *Matrix) float32 {
+	var max float32
+	for i := range a.Data {
+		diff := a.Data[i] - b.Data[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > max {
+			max = diff
+		}
+	}
+	return max
+}
+
+func TestFlashAttentionMatchesNaive(t *testing.T) {
+	e := NewEngine()
+
+	cases := []struct {
+		name       string
+		qRows      int
+		kvRows     int
+		causalMask bool
+	}{
+		{"square", 17, 17, false},
+		{"square-causal", 17, 17, true},
+		{"cross-attention", 17, 23, false},
+		{"cross-attention-small-kv", 23, 17, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Q := e.RandomMatrix(tc.qRows, 8)
+			K := e.RandomMatrix(tc.kvRows, 8)
+			V := e.RandomMatrix(tc.kvRows, 8)
+
+			want := naiveAttention(Q, K, V, tc.causalMask)
+
+			for _, blockSize := range []int{1, 2, 3, 4, 5, 7, 8, 16, 100} {
+				got := e.FlashAttention(Q, K, V, blockSize, tc.causalMask)
+				if diff := maxAbsDiff(want, got); diff > 1e-4 {
+					t.Errorf("blockSize=%d: max abs diff %.6f, want <= 1e-4", blockSize, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+//go:build cuda
+
+package adead
+
+/*
+#cgo LDFLAGS: -lcudart -lcublas -lcudnn
+#include <cuda_runtime.h>
+#include <cublas_v2.h>
+#include <cudnn.h>
+
+static cublasHandle_t adead_cublas_handle(void) {
+	static cublasHandle_t h = NULL;
+	if (h == NULL) {
+		cublasCreate(&h);
+	}
+	return h;
+}
+
+static cudnnHandle_t adead_cudnn_handle(void) {
+	static cudnnHandle_t h = NULL;
+	if (h == NULL) {
+		cudnnCreate(&h);
+	}
+	return h;
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+const gpuBuildTagEnabled = true
+
+// GPUMatrix is a device-resident float32 matrix backed by CUDA device
+// memory, handed out by devicePool so repeated same-shaped ops don't pay a
+// cudaMalloc/cudaFree on every call.
+type GPUMatrix struct {
+	ptr  unsafe.Pointer
+	rows int
+	cols int
+}
+
+var devicePool = newCudaPool()
+
+// cudaPool is a size-bucketed free list of CUDA device allocations.
+type cudaPool struct {
+	mu   sync.Mutex
+	free map[int][]unsafe.Pointer
+}
+
+func newCudaPool() *cudaPool {
+	return &cudaPool{free: make(map[int][]unsafe.Pointer)}
+}
+
+func (p *cudaPool) get(bytes int) unsafe.Pointer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if bucket := p.free[bytes]; len(bucket) > 0 {
+		ptr := bucket[len(bucket)-1]
+		p.free[bytes] = bucket[:len(bucket)-1]
+		return ptr
+	}
+	var dptr unsafe.Pointer
+	C.cudaMalloc(&dptr, C.size_t(bytes))
+	return dptr
+}
+
+func (p *cudaPool) put(bytes int, ptr unsafe.Pointer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.free[bytes] = append(p.free[bytes], ptr)
+}
+
+func gpuAvailable() bool {
+	var count C.int
+	C.cudaGetDeviceCount(&count)
+	return count > 0
+}
+
+// ToDevice copies m into pooled CUDA device memory.
+func (m *Matrix) ToDevice() *GPUMatrix {
+	bytes := len(m.Data) * 4
+	dptr := devicePool.get(bytes)
+	C.cudaMemcpy(dptr, unsafe.Pointer(&m.Data[0]), C.size_t(bytes), C.cudaMemcpyHostToDevice)
+	return &GPUMatrix{ptr: dptr, rows: m.Rows, cols: m.Cols}
+}
+
+// ToHost copies g back to a host Matrix and returns its device buffer to
+// the pool.
+func (g *GPUMatrix) ToHost() *Matrix {
+	out := Zeros(g.rows, g.cols)
+	bytes := len(out.Data) * 4
+	C.cudaMemcpy(unsafe.Pointer(&out.Data[0]), g.ptr, C.size_t(bytes), C.cudaMemcpyDeviceToHost)
+	devicePool.put(bytes, g.ptr)
+	return out
+}
+
+// gpuMatMul multiplies two row-major host matrices via cuBLAS sgemm.
+// cuBLAS expects column-major operands; rather than transpose both inputs,
+// it computes B^T * A^T = (A*B)^T, which read column-major is exactly A*B
+// read row-major.
+func gpuMatMul(a, b *Matrix) *Matrix {
+	da := a.ToDevice()
+	db := b.ToDevice()
+	dc := &GPUMatrix{ptr: devicePool.get(a.Rows * b.Cols * 4), rows: a.Rows, cols: b.Cols}
+
+	alpha, beta := C.float(1.0), C.float(0.0)
+	C.cublasSgemm(C.adead_cublas_handle(), C.CUBLAS_OP_N, C.CUBLAS_OP_N,
+		C.int(b.Cols), C.int(a.Rows), C.int(a.Cols),
+		&alpha,
+		(*C.float)(db.ptr), C.int(b.Cols),
+		(*C.float)(da.ptr), C.int(a.Cols),
+		&beta,
+		(*C.float)(dc.ptr), C.int(b.Cols))
+
+	devicePool.put(len(a.Data)*4, da.ptr)
+	devicePool.put(len(b.Data)*4, db.ptr)
+	return dc.ToHost()
+}
+
+// gpuAdd computes a+b via cuBLAS: copy b to the device, then saxpy a onto
+// it with alpha=1.
+func gpuAdd(a, b *Matrix) *Matrix {
+	da := a.ToDevice()
+	db := b.ToDevice()
+
+	alpha := C.float(1.0)
+	C.cublasSaxpy(C.adead_cublas_handle(), C.int(len(a.Data)),
+		&alpha, (*C.float)(da.ptr), 1, (*C.float)(db.ptr), 1)
+
+	devicePool.put(len(a.Data)*4, da.ptr)
+	return db.ToHost()
+}
+
+// gpuScale computes a*factor via cuBLAS sscal.
+func gpuScale(a *Matrix, factor float32) *Matrix {
+	da := a.ToDevice()
+	f := C.float(factor)
+	C.cublasSscal(C.adead_cublas_handle(), C.int(len(a.Data)), &f, (*C.float)(da.ptr), 1)
+	return da.ToHost()
+}
+
+// gpuSoftmax runs cuDNN's softmax over a single row treated as a
+// (1, len(data), 1, 1) NCHW tensor.
+func gpuSoftmax(data []float32) []float32 {
+	m := &Matrix{Data: data, Rows: 1, Cols: len(data)}
+	d := m.ToDevice()
+	defer func() { devicePool.put(len(data)*4, d.ptr) }()
+
+	var desc C.cudnnTensorDescriptor_t
+	C.cudnnCreateTensorDescriptor(&desc)
+	defer C.cudnnDestroyTensorDescriptor(desc)
+	C.cudnnSetTensor4dDescriptor(desc, C.CUDNN_TENSOR_NCHW, C.CUDNN_DATA_FLOAT,
+		1, C.int(len(data)), 1, 1)
+
+	alpha, beta := C.float(1.0), C.float(0.0)
+	out := devicePool.get(len(data) * 4)
+	C.cudnnSoftmaxForward(C.adead_cudnn_handle(), C.CUDNN_SOFTMAX_ACCURATE, C.CUDNN_SOFTMAX_MODE_INSTANCE,
+		&alpha, desc, d.ptr, &beta, desc, out)
+
+	result := Zeros(1, len(data))
+	C.cudaMemcpy(unsafe.Pointer(&result.Data[0]), out, C.size_t(len(data)*4), C.cudaMemcpyDeviceToHost)
+	devicePool.put(len(data)*4, out)
+	return result.Data
+}
@@ -0,0 +1,117 @@
+//go:build metal && darwin && arm64
+
+package adead
+
+/*
+#cgo LDFLAGS: -framework Metal -framework MetalPerformanceShaders -framework Foundation
+#include "metal_shim_darwin_arm64.h"
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+const gpuBuildTagEnabled = true
+
+// GPUMatrix is a device-resident float32 matrix backed by a shared-memory
+// MTLBuffer, handed out by devicePool so repeated same-shaped ops don't
+// pay an alloc on every call.
+type GPUMatrix struct {
+	buf  C.adead_metal_buffer
+	rows int
+	cols int
+}
+
+var devicePool = newMetalPool()
+
+// metalPool is a size-bucketed free list of MTLBuffers, guarded by a mutex
+// like devicePool's cudaPool counterpart since Engine is meant to be safe
+// for concurrent use.
+type metalPool struct {
+	mu   sync.Mutex
+	free map[int][]C.adead_metal_buffer
+}
+
+func newMetalPool() *metalPool {
+	return &metalPool{free: make(map[int][]C.adead_metal_buffer)}
+}
+
+func (p *metalPool) get(bytes int) C.adead_metal_buffer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if bucket := p.free[bytes]; len(bucket) > 0 {
+		buf := bucket[len(bucket)-1]
+		p.free[bytes] = bucket[:len(bucket)-1]
+		return buf
+	}
+	return C.adead_metal_alloc(C.size_t(bytes))
+}
+
+func (p *metalPool) put(bytes int, buf C.adead_metal_buffer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.free[bytes] = append(p.free[bytes], buf)
+}
+
+func gpuAvailable() bool {
+	return C.adead_metal_available() != 0
+}
+
+// ToDevice copies m into a pooled, shared-memory MTLBuffer.
+func (m *Matrix) ToDevice() *GPUMatrix {
+	bytes := len(m.Data) * 4
+	buf := devicePool.get(bytes)
+	C.adead_metal_copy_to_device(buf, (*C.float)(unsafe.Pointer(&m.Data[0])), C.size_t(len(m.Data)))
+	return &GPUMatrix{buf: buf, rows: m.Rows, cols: m.Cols}
+}
+
+// ToHost copies g back to a host Matrix and returns its buffer to the pool.
+func (g *GPUMatrix) ToHost() *Matrix {
+	out := Zeros(g.rows, g.cols)
+	C.adead_metal_copy_to_host((*C.float)(unsafe.Pointer(&out.Data[0])), g.buf, C.size_t(len(out.Data)))
+	devicePool.put(len(out.Data)*4, g.buf)
+	return out
+}
+
+// gpuMatMul multiplies two row-major host matrices via
+// MPSMatrixMultiplication.
+func gpuMatMul(a, b *Matrix) *Matrix {
+	da := a.ToDevice()
+	db := b.ToDevice()
+	dc := &GPUMatrix{buf: devicePool.get(a.Rows * b.Cols * 4), rows: a.Rows, cols: b.Cols}
+
+	C.adead_metal_matmul(da.buf, C.int(a.Rows), C.int(a.Cols), db.buf, C.int(b.Cols), dc.buf)
+
+	devicePool.put(len(a.Data)*4, da.buf)
+	devicePool.put(len(b.Data)*4, db.buf)
+	return dc.ToHost()
+}
+
+// gpuAdd computes a+b in a shared MTLBuffer.
+func gpuAdd(a, b *Matrix) *Matrix {
+	da := a.ToDevice()
+	db := b.ToDevice()
+	C.adead_metal_add(db.buf, da.buf, C.size_t(len(a.Data)))
+	devicePool.put(len(a.Data)*4, da.buf)
+	return db.ToHost()
+}
+
+// gpuScale computes a*factor in place on a shared MTLBuffer.
+func gpuScale(a *Matrix, factor float32) *Matrix {
+	da := a.ToDevice()
+	C.adead_metal_scale(da.buf, C.float(factor), C.size_t(len(a.Data)))
+	return da.ToHost()
+}
+
+// gpuSoftmax runs softmax over a single row on a shared MTLBuffer.
+func gpuSoftmax(data []float32) []float32 {
+	m := &Matrix{Data: data, Rows: 1, Cols: len(data)}
+	src := m.ToDevice()
+	dst := &GPUMatrix{buf: devicePool.get(len(data) * 4), rows: 1, cols: len(data)}
+
+	C.adead_metal_softmax(dst.buf, src.buf, C.size_t(len(data)))
+
+	devicePool.put(len(data)*4, src.buf)
+	return dst.ToHost().Data
+}
@@ -0,0 +1,33 @@
+//go:build !cuda && !(metal && darwin && arm64)
+
+package adead
+
+// gpuBuildTagEnabled is false when the binary was built without -tags cuda
+// or -tags metal; there is no device backend compiled in at all.
+const gpuBuildTagEnabled = false
+
+const gpuUnavailableMsg = "adead: EngineConfig.UseGPU is true but this binary was built without GPU support; rebuild with -tags cuda (Linux/Windows + NVIDIA) or -tags metal (darwin/arm64)"
+
+// GPUMatrix is a device-resident matrix handle. This build has no device to
+// be resident on; see ToDevice.
+type GPUMatrix struct {
+	rows, cols int
+}
+
+func gpuAvailable() bool { return false }
+
+// ToDevice would copy m to GPU memory. Without a cuda or metal build tag
+// there is no device, so this panics with instructions on how to get one.
+func (m *Matrix) ToDevice() *GPUMatrix {
+	panic(gpuUnavailableMsg)
+}
+
+// ToHost would copy a GPUMatrix back to the host; see ToDevice.
+func (g *GPUMatrix) ToHost() *Matrix {
+	panic(gpuUnavailableMsg)
+}
+
+func gpuMatMul(a, b *Matrix) *Matrix             { panic(gpuUnavailableMsg) }
+func gpuAdd(a, b *Matrix) *Matrix                { panic(gpuUnavailableMsg) }
+func gpuScale(a *Matrix, factor float32) *Matrix { panic(gpuUnavailableMsg) }
+func gpuSoftmax(data []float32) []float32        { panic(gpuUnavailableMsg) }
@@ -0,0 +1,43 @@
+package adead
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkMatMul compares the scalar fallback against whatever SIMD level
+// this machine's CPU actually supports, across a range of sizes; on an
+// AVX-512 host the AVX2 kernel is timed as well. All engines share the
+// default config (same NumThreads) apart from the kernel. Run with
+// -bench=MatMul -benchtime=1x; each sub-benchmark is named after its
+// SIMDLevel() so results can be attributed to the right kernel.
+func BenchmarkMatMul(b *testing.B) {
+	sizes := []int{64, 128, 256, 512, 1024, 2048}
+
+	scalarCfg := DefaultConfig()
+	scalarCfg.ForceScalar = true
+	scalar := NewEngineWithConfig(scalarCfg)
+	native := NewEngine()
+
+	engines := []*Engine{scalar}
+	if native.simd == simdAVX512 {
+		avx2 := NewEngine()
+		avx2.simd = simdAVX2
+		engines = append(engines, avx2)
+	}
+	engines = append(engines, native)
+
+	for _, n := range sizes {
+		a := scalar.RandomMatrix(n, n)
+		x := scalar.RandomMatrix(n, n)
+
+		for _, eng := range engines {
+			eng := eng
+			b.Run(fmt.Sprintf("%s/%d", eng.SIMDLevel(), n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					eng.MatMul(a, x)
+				}
+			})
+		}
+	}
+}
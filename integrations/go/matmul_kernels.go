@@ -0,0 +1,99 @@
+package adead
+
+// This file holds the MatMul kernel Engine.MatMul dispatches into once it
+// has picked a simdLevel and a row range to work on. lanes (0, 8, or 16)
+// says how many float32s the AVX2/AVX-512 kernels in simd_kernels_amd64.s
+// handle per instruction; the tail that doesn't fill a full lane group
+// always falls back to the scalar loop here, and lanes == 0 skips the
+// vector kernels entirely for the portable path.
+
+// matMulInto computes result[start:end, :] += a[start:end, :] @ b for the
+// blocked multiplication, dispatching the innermost jj loop's lane-aligned
+// bulk to fmaVec8/fmaVec16 (0 means no vector kernel, i.e. the portable
+// scalar path). Engine.MatMul calls this once per worker-pool chunk, so
+// result rows written by different calls never overlap.
+func matMulInto(result, a, b *Matrix, start, end, lanes int) {
+	n, k := b.Cols, a.Cols
+	const block = 32
+
+	for i := start; i < end; i += block {
+		iMax := minInt(i+block, end)
+		for j := 0; j < n; j += block {
+			jMax := minInt(j+block, n)
+			for kk := 0; kk < k; kk += block {
+				kMax := minInt(kk+block, k)
+
+				for ii := i; ii < iMax; ii++ {
+					row := result.Data[ii*n : ii*n+n]
+					for kkk := kk; kkk < kMax; kkk++ {
+						aVal := a.Get(ii, kkk)
+						bRow := b.Data[kkk*b.Cols : kkk*b.Cols+b.Cols]
+
+						jj := j
+						if lanes > 0 {
+							jjEnd := j + (jMax-j)/lanes*lanes
+							switch lanes {
+							case 16:
+								fmaVec16(row[jj:jjEnd], bRow[jj:jjEnd], aVal)
+							case 8:
+								fmaVec8(row[jj:jjEnd], bRow[jj:jjEnd], aVal)
+							}
+							jj = jjEnd
+						}
+						for ; jj < jMax; jj++ {
+							row[jj] += aVal * bRow[jj]
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// addLanes computes dst = a + b, dispatching the lane-aligned bulk of the
+// slice to addVec8/addVec16 and handling the remainder with a scalar loop.
+// lanes == 0 runs the portable scalar loop over the whole slice.
+func addLanes(dst, a, b []float32, lanes int) {
+	i := 0
+	if lanes > 0 {
+		aligned := len(dst) / lanes * lanes
+		switch lanes {
+		case 16:
+			addVec16(dst[:aligned], a[:aligned], b[:aligned])
+		case 8:
+			addVec8(dst[:aligned], a[:aligned], b[:aligned])
+		}
+		i = aligned
+	}
+	for ; i < len(dst); i++ {
+		dst[i] = a[i] + b[i]
+	}
+}
+
+// scaleLanes computes dst = a * factor, dispatching the lane-aligned bulk
+// of the slice to scaleVec8/scaleVec16 and handling the remainder with a
+// scalar loop. lanes == 0 runs the portable scalar loop over the whole
+// slice.
+func scaleLanes(dst, a []float32, factor float32, lanes int) {
+	i := 0
+	if lanes > 0 {
+		aligned := len(dst) / lanes * lanes
+		switch lanes {
+		case 16:
+			scaleVec16(dst[:aligned], a[:aligned], factor)
+		case 8:
+			scaleVec8(dst[:aligned], a[:aligned], factor)
+		}
+		i = aligned
+	}
+	for ; i < len(dst); i++ {
+		dst[i] = a[i] * factor
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
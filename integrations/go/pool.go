@@ -0,0 +1,96 @@
+package adead
+
+import "sync"
+
+// workerPool is a fixed-size set of goroutines reading off a shared job
+// channel, sized to EngineConfig.NumThreads. It's created lazily on first
+// use so an Engine that never parallelizes anything never spawns a single
+// extra goroutine.
+type workerPool struct {
+	jobs chan func()
+	done sync.WaitGroup
+}
+
+func newWorkerPool(n int) *workerPool {
+	if n < 1 {
+		n = 1
+	}
+	p := &workerPool{jobs: make(chan func())}
+	p.done.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer p.done.Done()
+			for job := range p.jobs {
+				job()
+			}
+		}()
+	}
+	return p
+}
+
+func (p *workerPool) close() {
+	close(p.jobs)
+	p.done.Wait()
+}
+
+// workers lazily creates this engine's worker pool, sized to
+// EngineConfig.NumThreads.
+func (e *Engine) workers() *workerPool {
+	e.poolOnce.Do(func() {
+		n := e.config.NumThreads
+		if n < 1 {
+			n = 1
+		}
+		e.pool = newWorkerPool(n)
+	})
+	return e.pool
+}
+
+// Parallel splits [0, n) into up to NumThreads contiguous chunks and runs fn
+// over each chunk on the engine's worker pool, blocking until every chunk
+// completes. It's the same primitive MatMul/Add/Scale/Softmax/Sigmoid/ReLU
+// use internally, exposed so callers can write their own parallel
+// reductions without paying for a goroutine per call.
+func (e *Engine) Parallel(n int, fn func(start, end int)) {
+	if n <= 0 {
+		return
+	}
+
+	workers := e.config.NumThreads
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers == 1 {
+		fn(0, n)
+		return
+	}
+
+	pool := e.workers()
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		s, e2 := start, end
+		pool.jobs <- func() {
+			defer wg.Done()
+			fn(s, e2)
+		}
+	}
+	wg.Wait()
+}
+
+// Close releases this engine's worker pool goroutines. Safe to call on an
+// engine that never parallelized anything (the pool was never created).
+func (e *Engine) Close() {
+	if e.pool != nil {
+		e.pool.close()
+	}
+}
@@ -0,0 +1,309 @@
+package adead
+
+import "math"
+
+// Precision selects the numeric format Engine ops compute in.
+type Precision int
+
+const (
+	// Float32 is full single precision (the default).
+	Float32 Precision = iota
+	// Float16 is IEEE 754 half precision (1s/5e/10m).
+	Float16
+	// BFloat16 keeps float32's 8-bit exponent but truncates the mantissa
+	// to 7 bits, trading mantissa precision for the same dynamic range.
+	BFloat16
+	// Int8 quantizes to signed 8-bit integers with a float32 scale,
+	// computed via QuantizedMatMul's int32 accumulator.
+	Int8
+)
+
+// String returns the human-readable name of the precision.
+func (p Precision) String() string {
+	switch p {
+	case Float16:
+		return "float16"
+	case BFloat16:
+		return "bfloat16"
+	case Int8:
+		return "int8"
+	default:
+		return "float32"
+	}
+}
+
+// MatrixF16 is a matrix stored as IEEE 754 half-precision floats.
+type MatrixF16 struct {
+	Data []uint16
+	Rows int
+	Cols int
+}
+
+// MatrixBF16 is a matrix stored as bfloat16 (truncated float32) values.
+type MatrixBF16 struct {
+	Data []uint16
+	Rows int
+	Cols int
+}
+
+// MatrixI8 is a matrix of signed 8-bit integers, quantized from a float32
+// Matrix relative to one or more scale factors (see QuantizeTensor /
+// QuantizeRows). The true value of an element is Data[i]*scale.
+type MatrixI8 struct {
+	Data []int8
+	Rows int
+	Cols int
+}
+
+// ToF16 quantizes m to half precision.
+func (m *Matrix) ToF16() *MatrixF16 {
+	out := &MatrixF16{Data: make([]uint16, len(m.Data)), Rows: m.Rows, Cols: m.Cols}
+	for i, v := range m.Data {
+		out.Data[i] = float32ToFloat16(v)
+	}
+	return out
+}
+
+// ToFloat32 dequantizes a half-precision matrix back to float32.
+func (m *MatrixF16) ToFloat32() *Matrix {
+	out := Zeros(m.Rows, m.Cols)
+	for i, v := range m.Data {
+		out.Data[i] = float16ToFloat32(v)
+	}
+	return out
+}
+
+// ToBF16 quantizes m to bfloat16.
+func (m *Matrix) ToBF16() *MatrixBF16 {
+	out := &MatrixBF16{Data: make([]uint16, len(m.Data)), Rows: m.Rows, Cols: m.Cols}
+	for i, v := range m.Data {
+		out.Data[i] = float32ToBFloat16(v)
+	}
+	return out
+}
+
+// ToFloat32 dequantizes a bfloat16 matrix back to float32.
+func (m *MatrixBF16) ToFloat32() *Matrix {
+	out := Zeros(m.Rows, m.Cols)
+	for i, v := range m.Data {
+		out.Data[i] = bfloat16ToFloat32(v)
+	}
+	return out
+}
+
+// QuantizeTensor quantizes m to int8 using a single scale for the whole
+// tensor, derived from its largest-magnitude element.
+func QuantizeTensor(m *Matrix) (*MatrixI8, float32) {
+	var absMax float32
+	for _, v := range m.Data {
+		if a := float32(math.Abs(float64(v))); a > absMax {
+			absMax = a
+		}
+	}
+	scale := tensorScale(absMax)
+
+	out := &MatrixI8{Data: make([]int8, len(m.Data)), Rows: m.Rows, Cols: m.Cols}
+	for i, v := range m.Data {
+		out.Data[i] = quantizeValue(v, scale)
+	}
+	return out, scale
+}
+
+// QuantizeRows quantizes m to int8 with one scale per row, which tracks
+// per-row dynamic range more tightly than a single tensor-wide scale.
+func QuantizeRows(m *Matrix) (*MatrixI8, []float32) {
+	scales := make([]float32, m.Rows)
+	out := &MatrixI8{Data: make([]int8, len(m.Data)), Rows: m.Rows, Cols: m.Cols}
+
+	for r := 0; r < m.Rows; r++ {
+		row := m.Data[r*m.Cols : (r+1)*m.Cols]
+		var absMax float32
+		for _, v := range row {
+			if a := float32(math.Abs(float64(v))); a > absMax {
+				absMax = a
+			}
+		}
+		scale := tensorScale(absMax)
+		scales[r] = scale
+
+		outRow := out.Data[r*m.Cols : (r+1)*m.Cols]
+		for i, v := range row {
+			outRow[i] = quantizeValue(v, scale)
+		}
+	}
+	return out, scales
+}
+
+// DequantizeTensor dequantizes q back to float32 using a single tensor-wide
+// scale, the inverse of QuantizeTensor.
+func (q *MatrixI8) DequantizeTensor(scale float32) *Matrix {
+	out := Zeros(q.Rows, q.Cols)
+	for i, v := range q.Data {
+		out.Data[i] = float32(v) * scale
+	}
+	return out
+}
+
+// DequantizeRows dequantizes q back to float32 using one scale per row, the
+// inverse of QuantizeRows.
+func (q *MatrixI8) DequantizeRows(scales []float32) *Matrix {
+	out := Zeros(q.Rows, q.Cols)
+	for r := 0; r < q.Rows; r++ {
+		scale := scales[r]
+		row := q.Data[r*q.Cols : (r+1)*q.Cols]
+		outRow := out.Data[r*q.Cols : (r+1)*q.Cols]
+		for i, v := range row {
+			outRow[i] = float32(v) * scale
+		}
+	}
+	return out
+}
+
+// tensorScale picks the smallest scale that keeps absMax inside int8's
+// [-127, 127] range.
+func tensorScale(absMax float32) float32 {
+	if absMax == 0 {
+		return 1
+	}
+	return absMax / 127
+}
+
+// quantizeValue rounds v/scale to the nearest int8, saturating at ±127
+// instead of wrapping on overflow.
+func quantizeValue(v, scale float32) int8 {
+	q := math.Round(float64(v / scale))
+	switch {
+	case q > 127:
+		return 127
+	case q < -127:
+		return -127
+	default:
+		return int8(q)
+	}
+}
+
+// QuantizedMatMul multiplies two int8-quantized matrices using an int32
+// accumulator, then rescales the result back to float32 by aScale*bScale.
+func (e *Engine) QuantizedMatMul(a *MatrixI8, aScale float32, b *MatrixI8, bScale float32) *Matrix {
+	if a.Cols != b.Rows {
+		panic("incompatible dimensions")
+	}
+
+	m, n, k := a.Rows, b.Cols, a.Cols
+	result := Zeros(m, n)
+	outScale := aScale * bScale
+
+	acc := make([]int32, n)
+	for i := 0; i < m; i++ {
+		aRow := a.Data[i*a.Cols : i*a.Cols+k]
+
+		for j := range acc {
+			acc[j] = 0
+		}
+		for kk := 0; kk < k; kk++ {
+			aVal := int32(aRow[kk])
+			if aVal == 0 {
+				continue
+			}
+			bRow := b.Data[kk*b.Cols : kk*b.Cols+n]
+			for j := 0; j < n; j++ {
+				acc[j] += aVal * int32(bRow[j])
+			}
+		}
+
+		outRow := result.Data[i*n : i*n+n]
+		for j, v := range acc {
+			outRow[j] = float32(v) * outScale
+		}
+	}
+
+	return result
+}
+
+// roundTrip quantizes and immediately dequantizes m at the given precision,
+// emulating the accuracy loss of computing in that format without needing a
+// typed kernel for every op. Float32 is a no-op.
+func roundTrip(m *Matrix, p Precision) *Matrix {
+	switch p {
+	case Float16:
+		return m.ToF16().ToFloat32()
+	case BFloat16:
+		return m.ToBF16().ToFloat32()
+	default:
+		return m
+	}
+}
+
+// roundTripVec is roundTrip for a flat slice, used by ops like Softmax that
+// don't operate on a Matrix.
+func roundTripVec(data []float32, p Precision) []float32 {
+	if p != Float16 && p != BFloat16 {
+		return data
+	}
+	m := &Matrix{Data: data, Rows: 1, Cols: len(data)}
+	return roundTrip(m, p).Data
+}
+
+// float32ToFloat16 converts a float32 to IEEE 754 half precision, rounding
+// to nearest and flushing to zero/infinity on under/overflow.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16((bits >> 16) & 0x8000)
+	exp := int32((bits>>23)&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case exp <= 0:
+		return sign
+	case exp >= 0x1f:
+		return sign | 0x7c00
+	default:
+		h := uint32(sign) | uint32(exp)<<10 | mant>>13
+		// Round to nearest even on the 13 dropped mantissa bits; a carry out
+		// of the mantissa bumps the exponent, up to infinity.
+		if rem := mant & 0x1fff; rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+			h++
+		}
+		return uint16(h)
+	}
+}
+
+// float16ToFloat32 converts an IEEE 754 half-precision value back to
+// float32.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := int32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	if exp == 0 {
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal half -> normalized float32
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+		exp++
+		mant &= 0x3ff
+	} else if exp == 0x1f {
+		return math.Float32frombits(sign | 0x7f800000 | (mant << 13))
+	}
+
+	exp = exp - 15 + 127
+	return math.Float32frombits(sign | uint32(exp)<<23 | mant<<13)
+}
+
+// float32ToBFloat16 truncates a float32 to bfloat16 by keeping its top 16
+// bits (sign, 8-bit exponent, 7-bit mantissa), rounding to nearest even.
+func float32ToBFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	rounded := bits + 0x7fff + ((bits >> 16) & 1)
+	return uint16(rounded >> 16)
+}
+
+// bfloat16ToFloat32 widens a bfloat16 back to float32 by shifting it into
+// the top 16 bits and zero-filling the rest of the mantissa.
+func bfloat16ToFloat32(b uint16) float32 {
+	return math.Float32frombits(uint32(b) << 16)
+}
@@ -0,0 +1,32 @@
+package adead
+
+import "testing"
+
+func TestQuantizedMatMulMatchesFloat(t *testing.T) {
+	e := NewEngine()
+
+	a := e.RandomMatrix(9, 13)
+	b := e.RandomMatrix(13, 7)
+	want := e.MatMul(a, b)
+
+	aq, aScale := QuantizeTensor(a)
+	bq, bScale := QuantizeTensor(b)
+	got := e.QuantizedMatMul(aq, aScale, bq, bScale)
+
+	if got.Rows != want.Rows || got.Cols != want.Cols {
+		t.Fatalf("shape mismatch: got %dx%d, want %dx%d", got.Rows, got.Cols, want.Rows, want.Cols)
+	}
+
+	// int8 quantization is lossy; this only checks QuantizedMatMul computes
+	// the GEMM its inputs actually represent, within quantization error.
+	const tol = 0.15
+	for i := range want.Data {
+		diff := got.Data[i] - want.Data[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tol {
+			t.Errorf("index %d: got %.4f, want %.4f (diff %.4f > tol %.2f)", i, got.Data[i], want.Data[i], diff, tol)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build amd64
+
+package adead
+
+import "golang.org/x/sys/cpu"
+
+// simdLevel identifies the widest vector ISA an Engine will dispatch to.
+type simdLevel int
+
+const (
+	simdScalar simdLevel = iota
+	simdAVX2
+	simdAVX512
+)
+
+// detectSIMD probes CPUID once and returns the widest ISA this process can
+// safely use. AVX-512 is only picked when both F and DQ are reported, though
+// the 512-bit kernels themselves use AVX-512F instructions only; AVX2
+// requires FMA so the 8-wide kernel can fuse multiply-adds instead of
+// issuing them separately.
+func detectSIMD() simdLevel {
+	switch {
+	case cpu.X86.HasAVX512F && cpu.X86.HasAVX512DQ:
+		return simdAVX512
+	case cpu.X86.HasAVX2 && cpu.X86.HasFMA:
+		return simdAVX2
+	default:
+		return simdScalar
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !amd64
+
+package adead
+
+// simdLevel identifies the widest vector ISA an Engine will dispatch to.
+// Non-amd64 builds only ever run the portable scalar kernels.
+type simdLevel int
+
+const (
+	simdScalar simdLevel = iota
+	simdAVX2
+	simdAVX512
+)
+
+// detectSIMD always reports simdScalar on non-amd64 targets; there is no
+// AVX2/AVX-512 to probe for.
+func detectSIMD() simdLevel {
+	return simdScalar
+}
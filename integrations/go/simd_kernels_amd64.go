@@ -0,0 +1,26 @@
+//go:build amd64
+
+package adead
+
+// Hand-written AVX2 (8-wide) and AVX-512 (16-wide) kernels backing
+// addLanes/scaleLanes/matMulInto's lane-aligned bulk; see
+// simd_kernels_amd64.s. Each pair operates on a lanes-wide slice and leaves
+// any remainder to the scalar loop in matmul_kernels.go.
+
+//go:noescape
+func addVec8(dst, a, b []float32)
+
+//go:noescape
+func addVec16(dst, a, b []float32)
+
+//go:noescape
+func scaleVec8(dst, a []float32, factor float32)
+
+//go:noescape
+func scaleVec16(dst, a []float32, factor float32)
+
+//go:noescape
+func fmaVec8(dst, b []float32, aVal float32)
+
+//go:noescape
+func fmaVec16(dst, b []float32, aVal float32)
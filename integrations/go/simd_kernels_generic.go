@@ -0,0 +1,35 @@
+//go:build !amd64
+
+package adead
+
+// Plain-Go equivalents of simd_kernels_amd64.s's vector kernels. detectSIMD
+// never returns simdAVX2/simdAVX512 off amd64 (see simd_generic.go), so
+// these only exist to keep matmul_kernels.go's callers architecture-
+// independent; they are not on any hot path on this target.
+
+func addVec8(dst, a, b []float32)  { addVecGeneric(dst, a, b) }
+func addVec16(dst, a, b []float32) { addVecGeneric(dst, a, b) }
+
+func scaleVec8(dst, a []float32, factor float32)  { scaleVecGeneric(dst, a, factor) }
+func scaleVec16(dst, a []float32, factor float32) { scaleVecGeneric(dst, a, factor) }
+
+func fmaVec8(dst, b []float32, aVal float32)  { fmaVecGeneric(dst, b, aVal) }
+func fmaVec16(dst, b []float32, aVal float32) { fmaVecGeneric(dst, b, aVal) }
+
+func addVecGeneric(dst, a, b []float32) {
+	for i := range dst {
+		dst[i] = a[i] + b[i]
+	}
+}
+
+func scaleVecGeneric(dst, a []float32, factor float32) {
+	for i := range dst {
+		dst[i] = a[i] * factor
+	}
+}
+
+func fmaVecGeneric(dst, b []float32, aVal float32) {
+	for i := range dst {
+		dst[i] += aVal * b[i]
+	}
+}
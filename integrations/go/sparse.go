@@ -0,0 +1,184 @@
+package adead
+
+// Triplet is a COO (coordinate-format) builder for sparse matrices: entries
+// are appended with Put in any order, duplicates included, and then
+// compacted into a SparseCSR via ToCSR. This mirrors the triplet/CSR split
+// used by most sparse linear algebra libraries, where COO is cheap to build
+// incrementally but CSR is what the multiply kernels actually want.
+type Triplet struct {
+	rows, cols int
+	i, j       []int
+	v          []float32
+}
+
+// NewTriplet creates an empty triplet for an rows x cols matrix. nnzHint
+// preallocates storage for the expected number of non-zeros; it is only a
+// hint and Put grows the backing slices past it if needed.
+func NewTriplet(rows, cols, nnzHint int) *Triplet {
+	return &Triplet{
+		rows: rows,
+		cols: cols,
+		i:    make([]int, 0, nnzHint),
+		j:    make([]int, 0, nnzHint),
+		v:    make([]float32, 0, nnzHint),
+	}
+}
+
+// Put appends an entry at (row, col). Putting the same coordinate twice
+// accumulates: ToCSR sums duplicate entries, the same convention gosl's
+// Triplet uses.
+func (t *Triplet) Put(i, j int, v float32) {
+	if i < 0 || i >= t.rows || j < 0 || j >= t.cols {
+		panic("sparse: coordinate out of range")
+	}
+	t.i = append(t.i, i)
+	t.j = append(t.j, j)
+	t.v = append(t.v, v)
+}
+
+// Len returns the number of entries pushed so far, including duplicates.
+func (t *Triplet) Len() int {
+	return len(t.v)
+}
+
+// ToCSR compacts the triplet into compressed-sparse-row storage, summing any
+// duplicate (row, col) entries.
+func (t *Triplet) ToCSR() *SparseCSR {
+	rowStart := make([]int, t.rows+1)
+	for _, r := range t.i {
+		rowStart[r+1]++
+	}
+	for r := 0; r < t.rows; r++ {
+		rowStart[r+1] += rowStart[r]
+	}
+
+	nnz := len(t.v)
+	colIdx := make([]int, nnz)
+	vals := make([]float32, nnz)
+	cursor := append([]int(nil), rowStart[:t.rows]...)
+
+	for k := 0; k < nnz; k++ {
+		r := t.i[k]
+		pos := cursor[r]
+		colIdx[pos] = t.j[k]
+		vals[pos] = t.v[k]
+		cursor[r]++
+	}
+
+	csr := &SparseCSR{Rows: t.rows, Cols: t.cols, RowStart: rowStart, ColIdx: colIdx, Vals: vals}
+	csr.sumDuplicates()
+	return csr
+}
+
+// SparseCSR is a matrix in compressed-sparse-row format: row r's entries
+// live in ColIdx[RowStart[r]:RowStart[r+1]] / Vals[RowStart[r]:RowStart[r+1]].
+type SparseCSR struct {
+	Rows, Cols int
+	RowStart   []int
+	ColIdx     []int
+	Vals       []float32
+}
+
+// NNZ returns the number of stored non-zero entries.
+func (c *SparseCSR) NNZ() int {
+	return len(c.Vals)
+}
+
+// sumDuplicates collapses repeated (row, col) entries within each row,
+// produced when a Triplet Put the same coordinate more than once.
+func (c *SparseCSR) sumDuplicates() {
+	newColIdx := c.ColIdx[:0]
+	newVals := c.Vals[:0]
+	newRowStart := make([]int, c.Rows+1)
+
+	seen := make(map[int]int, 8)
+	for r := 0; r < c.Rows; r++ {
+		for k := range seen {
+			delete(seen, k)
+		}
+		start, end := c.RowStart[r], c.RowStart[r+1]
+		for k := start; k < end; k++ {
+			col := c.ColIdx[k]
+			if pos, ok := seen[col]; ok {
+				newVals[pos] += c.Vals[k]
+				continue
+			}
+			seen[col] = len(newVals)
+			newColIdx = append(newColIdx, col)
+			newVals = append(newVals, c.Vals[k])
+		}
+		newRowStart[r+1] = len(newVals)
+	}
+
+	c.RowStart = newRowStart
+	c.ColIdx = newColIdx
+	c.Vals = newVals
+}
+
+// Densify expands a SparseCSR into a dense Matrix.
+func (c *SparseCSR) Densify() *Matrix {
+	out := Zeros(c.Rows, c.Cols)
+	for r := 0; r < c.Rows; r++ {
+		start, end := c.RowStart[r], c.RowStart[r+1]
+		for k := start; k < end; k++ {
+			out.Set(r, c.ColIdx[k], c.Vals[k])
+		}
+	}
+	return out
+}
+
+// Sparsify builds a SparseCSR from a dense Matrix, dropping any entry whose
+// absolute value is <= threshold.
+func Sparsify(m *Matrix, threshold float32) *SparseCSR {
+	t := NewTriplet(m.Rows, m.Cols, len(m.Data)/4)
+	for r := 0; r < m.Rows; r++ {
+		for cCol := 0; cCol < m.Cols; cCol++ {
+			v := m.Get(r, cCol)
+			if v > threshold || v < -threshold {
+				t.Put(r, cCol, v)
+			}
+		}
+	}
+	return t.ToCSR()
+}
+
+// SpMatMul multiplies a sparse matrix by a dense one, skipping zero blocks
+// entirely instead of iterating a's full Rows x Cols extent like the dense
+// MatMul does.
+func (e *Engine) SpMatMul(a *SparseCSR, b *Matrix) *Matrix {
+	if a.Cols != b.Rows {
+		panic("incompatible dimensions")
+	}
+
+	result := Zeros(a.Rows, b.Cols)
+	for r := 0; r < a.Rows; r++ {
+		outRow := result.Data[r*b.Cols : (r+1)*b.Cols]
+		start, end := a.RowStart[r], a.RowStart[r+1]
+		for k := start; k < end; k++ {
+			aVal := a.Vals[k]
+			bRow := b.Data[a.ColIdx[k]*b.Cols : (a.ColIdx[k]+1)*b.Cols]
+			for j, bv := range bRow {
+				outRow[j] += aVal * bv
+			}
+		}
+	}
+	return result
+}
+
+// SpMV multiplies a sparse matrix by a dense vector.
+func (e *Engine) SpMV(a *SparseCSR, x []float32) []float32 {
+	if a.Cols != len(x) {
+		panic("incompatible dimensions")
+	}
+
+	out := make([]float32, a.Rows)
+	for r := 0; r < a.Rows; r++ {
+		var sum float32
+		start, end := a.RowStart[r], a.RowStart[r+1]
+		for k := start; k < end; k++ {
+			sum += a.Vals[k] * x[a.ColIdx[k]]
+		}
+		out[r] = sum
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package adead
+
+import "testing"
+
+func TestSpMatMulMatchesDense(t *testing.T) {
+	e := NewEngine()
+
+	dense := e.RandomMatrix(11, 9)
+	// Zero out most entries so Sparsify actually has something to skip.
+	for i := range dense.Data {
+		if i%3 != 0 {
+			dense.Data[i] = 0
+		}
+	}
+
+	csr := Sparsify(dense, 0)
+	if got := csr.Densify(); maxAbsDiff(got, dense) > 1e-6 {
+		t.Fatalf("Densify(Sparsify(m)) != m, max abs diff %.6f", maxAbsDiff(got, dense))
+	}
+
+	b := e.RandomMatrix(9, 5)
+	want := e.MatMul(dense, b)
+	got := e.SpMatMul(csr, b)
+
+	if diff := maxAbsDiff(want, got); diff > 1e-4 {
+		t.Errorf("SpMatMul max abs diff %.6f, want <= 1e-4", diff)
+	}
+}
+
+func TestSpMVMatchesDense(t *testing.T) {
+	e := NewEngine()
+
+	dense := e.RandomMatrix(7, 6)
+	for i := range dense.Data {
+		if i%2 == 0 {
+			dense.Data[i] = 0
+		}
+	}
+	csr := Sparsify(dense, 0)
+
+	x := make([]float32, 6)
+	for i := range x {
+		x[i] = float32(i) - 2.5
+	}
+
+	got := e.SpMV(csr, x)
+	for r := 0; r < dense.Rows; r++ {
+		var want float32
+		for c := 0; c < dense.Cols; c++ {
+			want += dense.Get(r, c) * x[c]
+		}
+		diff := got[r] - want
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > 1e-4 {
+			t.Errorf("row %d: got %.6f, want %.6f", r, got[r], want)
+		}
+	}
+}